@@ -0,0 +1,24 @@
+package compat
+
+import "errors"
+
+// ErrNotFound is returned by Backend methods when no container matches the
+// requested name. Handlers translate it to a 404 with a Docker-shaped body.
+var ErrNotFound = errors.New("no such container")
+
+// ErrConflict is returned by Backend.Create when a container with the
+// requested name already exists.
+var ErrConflict = errors.New("container already exists")
+
+// Backend is the narrow surface the compat handlers need from MiniRun's
+// container store. main wires an adapter over its active storage.Store so
+// this package stays independent of package main.
+type Backend interface {
+	ListContainers() ([]ContainerRecord, error)
+	GetContainer(name string) (*ContainerRecord, error)
+	CreateContainer(name, rootfs, command string) (*ContainerRecord, error)
+	DeleteContainer(name string) error
+	StartContainer(name string) error
+	StopContainer(name string) error
+	KillContainer(name string) error
+}
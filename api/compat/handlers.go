@@ -0,0 +1,281 @@
+package compat
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+var decoder = schema.NewDecoder()
+
+func init() {
+	decoder.IgnoreUnknownKeys(true)
+}
+
+// Handlers groups the compat endpoints behind a single Backend so RegisterRoutes
+// can wire them onto a caller-owned *mux.Router prefixed with the API version.
+type Handlers struct {
+	Backend Backend
+}
+
+// NewHandlers constructs a compat Handlers backed by the given store adapter.
+func NewHandlers(backend Backend) *Handlers {
+	return &Handlers{Backend: backend}
+}
+
+// RegisterRoutes mounts the Docker Engine API v1.41 surface under
+// /v1.41/... on router. There is no version-less alias: several of these
+// paths (e.g. POST /containers/{id}/start, DELETE /containers/{id}) are
+// identical to MiniRun's own native routes, and mounting an unprefixed
+// compat subrouter would have one set of handlers silently shadow the
+// other depending on registration order. Docker SDKs always send a version
+// prefix, so the versioned mount alone is sufficient.
+func (h *Handlers) RegisterRoutes(router *mux.Router) {
+	for _, prefix := range []string{"/v" + APIVersion} {
+		sub := router.PathPrefix(prefix).Subrouter()
+		sub.HandleFunc("/containers/json", h.listContainers).Methods("GET")
+		sub.HandleFunc("/containers/create", h.createContainer).Methods("POST")
+		sub.HandleFunc("/containers/{id}/start", h.startContainer).Methods("POST")
+		sub.HandleFunc("/containers/{id}/stop", h.stopContainer).Methods("POST")
+		sub.HandleFunc("/containers/{id}/kill", h.killContainer).Methods("POST")
+		sub.HandleFunc("/containers/{id}/wait", h.waitContainer).Methods("POST")
+		sub.HandleFunc("/containers/{id}", h.removeContainer).Methods("DELETE")
+		sub.HandleFunc("/containers/{id}/json", h.inspectContainer).Methods("GET")
+	}
+}
+
+// writeJSON encodes v as the response body with the status Docker clients expect.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError mirrors Docker's {"message": "..."} error body shape.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"message": err.Error()})
+}
+
+func toSummary(c ContainerRecord) ContainerSummary {
+	return ContainerSummary{
+		Id:      c.Name,
+		Names:   []string{"/" + c.Name},
+		Image:   c.RootFS,
+		Command: c.Command,
+		Created: c.CreatedAt.Unix(),
+		State:   c.Status,
+		Status:  capitalize(c.Status),
+		Labels:  map[string]string{},
+	}
+}
+
+// capitalize upper-cases the first byte of s, leaving the rest alone. Unlike
+// a direct s[:1]/s[1:] split, it doesn't panic when s is empty.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func toInspect(c ContainerRecord) ContainerJSON {
+	return ContainerJSON{
+		Id:      c.Name,
+		Created: c.CreatedAt.Format("2006-01-02T15:04:05.000000000Z07:00"),
+		Path:    c.Command,
+		Args:    strings.Fields(c.Command),
+		State: ContainerState{
+			Status:  c.Status,
+			Running: c.Status == "running",
+		},
+		Image: c.RootFS,
+		Name:  "/" + c.Name,
+	}
+}
+
+// listContainers handles GET /containers/json.
+func (h *Handlers) listContainers(w http.ResponseWriter, r *http.Request) {
+	var opts ListOptions
+	if err := decoder.Decode(&opts, r.URL.Query()); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// Docker-style `filters` query param is a JSON-encoded object, not a flat
+	// form field, so it's parsed separately rather than via gorilla/schema.
+	if raw := r.URL.Query().Get("filters"); raw != "" {
+		var filters map[string][]string
+		if err := json.Unmarshal([]byte(raw), &filters); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		opts.Filters = filters
+	}
+
+	records, err := h.Backend.ListContainers()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	summaries := make([]ContainerSummary, 0, len(records))
+	for _, c := range records {
+		if !opts.All && c.Status != "running" {
+			continue
+		}
+		if !matchesFilters(c, opts.Filters) {
+			continue
+		}
+		summaries = append(summaries, toSummary(c))
+	}
+	if opts.Limit > 0 && len(summaries) > opts.Limit {
+		summaries = summaries[:opts.Limit]
+	}
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+// matchesFilters reports whether c satisfies every filter key Docker's
+// `filters` query param supplied. MiniRun's ContainerRecord has no labels,
+// so only the "status" and "name" keys (the ones `docker ps -f` actually
+// exercises against this backend) are recognized; unknown keys are ignored
+// rather than rejected, matching Docker's own lenient behavior. Within a
+// key, values are OR'd together, same as Docker.
+func matchesFilters(c ContainerRecord, filters map[string][]string) bool {
+	if values, ok := filters["status"]; ok && !containsString(values, c.Status) {
+		return false
+	}
+	if values, ok := filters["name"]; ok && !anyContains(c.Name, values) {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func anyContains(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// createContainer handles POST /containers/create?name=....
+func (h *Handlers) createContainer(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, errBadParameter("name is required"))
+		return
+	}
+
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	command := strings.Join(req.Cmd, " ")
+	record, err := h.Backend.CreateContainer(name, req.Image, command)
+	if err != nil {
+		if err == ErrConflict {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, CreateResponse{Id: record.Name})
+}
+
+func (h *Handlers) startContainer(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["id"]
+	if err := h.Backend.StartContainer(name); err != nil {
+		h.writeBackendError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) stopContainer(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["id"]
+	if err := h.Backend.StopContainer(name); err != nil {
+		h.writeBackendError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) killContainer(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["id"]
+	if err := h.Backend.KillContainer(name); err != nil {
+		h.writeBackendError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// waitContainer handles POST /containers/{id}/wait. ContainerRecord carries
+// no exit code, so this does not block until the container exits: it
+// responds at once with StatusCode -1 for a running container and 0 otherwise.
+func (h *Handlers) waitContainer(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["id"]
+	record, err := h.Backend.GetContainer(name)
+	if err != nil {
+		h.writeBackendError(w, err)
+		return
+	}
+	statusCode := 0
+	if record.Status == "running" {
+		statusCode = -1 // still running, no exit code to report
+	}
+	writeJSON(w, http.StatusOK, WaitResponse{StatusCode: statusCode})
+}
+
+func (h *Handlers) removeContainer(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["id"]
+	var opts RemoveOptions
+	if err := decoder.Decode(&opts, r.URL.Query()); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.Backend.DeleteContainer(name); err != nil {
+		h.writeBackendError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handlers) inspectContainer(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["id"]
+	record, err := h.Backend.GetContainer(name)
+	if err != nil {
+		h.writeBackendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toInspect(*record))
+}
+
+func (h *Handlers) writeBackendError(w http.ResponseWriter, err error) {
+	if err == ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err)
+}
+
+type badParameter string
+
+func (e badParameter) Error() string { return string(e) }
+
+func errBadParameter(msg string) error { return badParameter(msg) }
@@ -0,0 +1,102 @@
+// Package compat implements a subset of the Docker Engine API (v1.41) on top
+// of MiniRun's own Container store, so existing `docker` CLI invocations and
+// SDKs can be pointed at a MiniRun server without modification.
+package compat
+
+import "time"
+
+// APIVersion is the Docker Engine API version this package targets.
+const APIVersion = "1.41"
+
+// ContainerRecord is the minimal view of a MiniRun container that the compat
+// layer needs in order to translate to/from Docker's JSON shapes. It is
+// intentionally decoupled from the orchestrator's own Container type so this
+// package has no dependency on package main; callers adapt their store to
+// the Backend interface in backend.go.
+type ContainerRecord struct {
+	Name      string
+	RootFS    string
+	Command   string
+	Status    string // created/running/stopped
+	CreatedAt time.Time
+}
+
+// ContainerSummary mirrors the shape returned by GET /containers/json.
+type ContainerSummary struct {
+	Id         string            `json:"Id"`
+	Names      []string          `json:"Names"`
+	Image      string            `json:"Image"`
+	Command    string            `json:"Command"`
+	Created    int64             `json:"Created"` // unix seconds
+	State      string            `json:"State"`
+	Status     string            `json:"Status"`
+	Labels     map[string]string `json:"Labels"`
+	HostConfig HostConfigSummary `json:"HostConfig"`
+}
+
+// HostConfigSummary is the subset of Docker's HostConfig surfaced on list/inspect.
+type HostConfigSummary struct {
+	NetworkMode string `json:"NetworkMode"`
+}
+
+// ContainerState mirrors Docker's nested "State" object on inspect responses.
+type ContainerState struct {
+	Status     string    `json:"Status"`
+	Running    bool      `json:"Running"`
+	Pid        int       `json:"Pid"`
+	ExitCode   int       `json:"ExitCode"`
+	StartedAt  time.Time `json:"StartedAt,omitempty"`
+	FinishedAt time.Time `json:"FinishedAt,omitempty"`
+}
+
+// ContainerJSON mirrors the shape returned by GET /containers/{id}/json (inspect).
+type ContainerJSON struct {
+	Id      string         `json:"Id"`
+	Created string         `json:"Created"` // RFC3339
+	Path    string         `json:"Path"`
+	Args    []string       `json:"Args"`
+	State   ContainerState `json:"State"`
+	Image   string         `json:"Image"`
+	Name    string         `json:"Name"` // leading slash, per Docker convention
+}
+
+// CreateRequest mirrors the subset of Docker's container-create body MiniRun honors.
+type CreateRequest struct {
+	Image      string   `json:"Image"`
+	Cmd        []string `json:"Cmd"`
+	HostConfig struct {
+		Binds []string `json:"Binds"`
+	} `json:"HostConfig"`
+}
+
+// CreateResponse mirrors POST /containers/create's response body.
+type CreateResponse struct {
+	Id       string   `json:"Id"`
+	Warnings []string `json:"Warnings"`
+}
+
+// WaitResponse mirrors POST /containers/{id}/wait's response body.
+type WaitResponse struct {
+	StatusCode int              `json:"StatusCode"`
+	Error      *WaitResponseErr `json:"Error,omitempty"`
+}
+
+// WaitResponseErr mirrors the nested error object in WaitResponse.
+type WaitResponseErr struct {
+	Message string `json:"Message"`
+}
+
+// ListOptions holds the parsed query parameters accepted by GET /containers/json.
+type ListOptions struct {
+	All     bool                `schema:"all"`
+	Limit   int                 `schema:"limit"`
+	Size    bool                `schema:"size"`
+	Filters map[string][]string `schema:"-"` // parsed separately; Docker encodes this as a JSON string
+}
+
+// RemoveOptions holds the parsed query parameters accepted by DELETE /containers/{id}.
+type RemoveOptions struct {
+	Force bool `schema:"force"`
+	V     bool `schema:"v"`
+	Link  bool `schema:"link"`
+}
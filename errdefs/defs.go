@@ -0,0 +1,41 @@
+// Package errdefs defines a typed error taxonomy for MiniRun. Each error kind
+// is a marker interface; callers check for a kind with the matching Is*
+// helper instead of comparing err.Error() strings, so wrapped errors (via
+// fmt.Errorf("...: %w", err) or similar) are still recognized correctly.
+package errdefs
+
+// NotFound is implemented by errors indicating a requested resource (e.g. a
+// container) does not exist. HTTP layers map it to 404.
+type NotFound interface {
+	NotFound()
+}
+
+// Conflict is implemented by errors indicating the request conflicts with
+// the resource's current state (e.g. a name already in use). Maps to 409.
+type Conflict interface {
+	Conflict()
+}
+
+// InvalidParameter is implemented by errors indicating malformed or missing
+// request input. Maps to 400.
+type InvalidParameter interface {
+	InvalidParameter()
+}
+
+// Unavailable is implemented by errors indicating a dependency (e.g. the
+// database) could not be reached. Maps to 503.
+type Unavailable interface {
+	Unavailable()
+}
+
+// Forbidden is implemented by errors indicating the caller is not permitted
+// to perform the operation. Maps to 403.
+type Forbidden interface {
+	Forbidden()
+}
+
+// System is implemented by errors indicating an unexpected internal failure.
+// Maps to 500.
+type System interface {
+	System()
+}
@@ -0,0 +1,134 @@
+package errdefs
+
+import "errors"
+
+// errWithCause is the concrete type underlying every constructor in this
+// package. It provides Error() and Unwrap(), so wrapped causes survive
+// errors.Is/errors.As/errors.Unwrap chains; each constructor embeds it in a
+// small wrapper type below that implements exactly one of the marker
+// interfaces from defs.go.
+type errWithCause struct {
+	kind  string
+	msg   string
+	cause error
+}
+
+func (e *errWithCause) Error() string {
+	if e.cause != nil {
+		return e.msg + ": " + e.cause.Error()
+	}
+	return e.msg
+}
+
+func (e *errWithCause) Unwrap() error { return e.cause }
+
+type notFoundErr struct{ *errWithCause }
+
+func (notFoundErr) NotFound() {}
+
+type conflictErr struct{ *errWithCause }
+
+func (conflictErr) Conflict() {}
+
+type invalidParameterErr struct{ *errWithCause }
+
+func (invalidParameterErr) InvalidParameter() {}
+
+type unavailableErr struct{ *errWithCause }
+
+func (unavailableErr) Unavailable() {}
+
+type forbiddenErr struct{ *errWithCause }
+
+func (forbiddenErr) Forbidden() {}
+
+type systemErr struct{ *errWithCause }
+
+func (systemErr) System() {}
+
+// NotFoundMsg constructs a new NotFound error with no wrapped cause.
+func NotFoundMsg(msg string) error {
+	return notFoundErr{&errWithCause{kind: "not_found", msg: msg}}
+}
+
+// WrapNotFound wraps cause as a NotFound error, preserving it for errors.Unwrap.
+func WrapNotFound(cause error, msg string) error {
+	return notFoundErr{&errWithCause{kind: "not_found", msg: msg, cause: cause}}
+}
+
+// ConflictMsg constructs a new Conflict error with no wrapped cause.
+func ConflictMsg(msg string) error {
+	return conflictErr{&errWithCause{kind: "conflict", msg: msg}}
+}
+
+// WrapConflict wraps cause as a Conflict error.
+func WrapConflict(cause error, msg string) error {
+	return conflictErr{&errWithCause{kind: "conflict", msg: msg, cause: cause}}
+}
+
+// InvalidParameterMsg constructs a new InvalidParameter error with no wrapped cause.
+func InvalidParameterMsg(msg string) error {
+	return invalidParameterErr{&errWithCause{kind: "invalid_parameter", msg: msg}}
+}
+
+// WrapInvalidParameter wraps cause as an InvalidParameter error.
+func WrapInvalidParameter(cause error, msg string) error {
+	return invalidParameterErr{&errWithCause{kind: "invalid_parameter", msg: msg, cause: cause}}
+}
+
+// WrapUnavailable wraps cause as an Unavailable error.
+func WrapUnavailable(cause error, msg string) error {
+	return unavailableErr{&errWithCause{kind: "unavailable", msg: msg, cause: cause}}
+}
+
+// ForbiddenMsg constructs a new Forbidden error with no wrapped cause.
+func ForbiddenMsg(msg string) error {
+	return forbiddenErr{&errWithCause{kind: "forbidden", msg: msg}}
+}
+
+// WrapForbidden wraps cause as a Forbidden error.
+func WrapForbidden(cause error, msg string) error {
+	return forbiddenErr{&errWithCause{kind: "forbidden", msg: msg, cause: cause}}
+}
+
+// WrapSystem wraps cause as a System error. Use this for unexpected internal
+// failures that should surface as 500s without leaking implementation detail.
+func WrapSystem(cause error, msg string) error {
+	return systemErr{&errWithCause{kind: "system", msg: msg, cause: cause}}
+}
+
+// IsNotFound reports whether err, or any error in its cause chain, is a NotFound.
+func IsNotFound(err error) bool {
+	var target NotFound
+	return errors.As(err, &target)
+}
+
+// IsConflict reports whether err, or any error in its cause chain, is a Conflict.
+func IsConflict(err error) bool {
+	var target Conflict
+	return errors.As(err, &target)
+}
+
+// IsInvalidParameter reports whether err, or any error in its cause chain, is an InvalidParameter.
+func IsInvalidParameter(err error) bool {
+	var target InvalidParameter
+	return errors.As(err, &target)
+}
+
+// IsUnavailable reports whether err, or any error in its cause chain, is an Unavailable.
+func IsUnavailable(err error) bool {
+	var target Unavailable
+	return errors.As(err, &target)
+}
+
+// IsForbidden reports whether err, or any error in its cause chain, is a Forbidden.
+func IsForbidden(err error) bool {
+	var target Forbidden
+	return errors.As(err, &target)
+}
+
+// IsSystem reports whether err, or any error in its cause chain, is a System error.
+func IsSystem(err error) bool {
+	var target System
+	return errors.As(err, &target)
+}
@@ -0,0 +1,26 @@
+package metrics
+
+// These are the concrete instruments GET /metrics exposes. They're defined
+// here, rather than in each caller, so every package that records against
+// a given metric (the orchestrator, the storage drivers, operations) shares
+// the same underlying series instead of each registering its own copy.
+var (
+	HTTPRequestsTotal = NewCounterVec(
+		"minirun_http_requests_total", "Total HTTP requests, by method, path, and status code.",
+		"method", "path", "code")
+
+	HTTPRequestDuration = NewHistogramVec(
+		"minirun_http_request_duration_seconds", "HTTP request latency in seconds, by method and path.",
+		"method", "path")
+
+	ContainersTotal = NewCounterVec(
+		"minirun_containers_total", "Container lifecycle events, by the status they resulted in.",
+		"status")
+
+	DBQueryDuration = NewHistogramVec(
+		"minirun_db_query_duration_seconds", "Storage query latency in seconds, by query name.",
+		"query")
+
+	OperationsInflight = NewGauge(
+		"minirun_operations_inflight", "Number of async operations currently running.")
+)
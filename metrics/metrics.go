@@ -0,0 +1,229 @@
+// Package metrics implements a minimal Prometheus-compatible metrics
+// registry and text-exposition HTTP handler: counters, gauges, and
+// histograms, rendered in the format GET /metrics is expected to serve.
+// It covers the subset of the client library MiniRun actually needs rather
+// than depending on the full upstream package.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// collector is implemented by every metric type so the registry can render
+// them uniformly from Handler.
+type collector interface {
+	writeTo(w io.Writer)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []collector
+)
+
+func register(c collector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, c)
+}
+
+// Handler serves every registered metric in Prometheus's text exposition
+// format (GET /metrics).
+func Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		for _, c := range registry {
+			c.writeTo(w)
+		}
+	}
+}
+
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, "\x00")
+}
+
+func formatLabels(labelNames, labelValues []string) string {
+	if len(labelNames) == 0 {
+		return ""
+	}
+	parts := make([]string, len(labelNames))
+	for i, name := range labelNames {
+		parts[i] = fmt.Sprintf("%s=%q", name, labelValues[i])
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// CounterVec is a monotonically increasing metric, partitioned by label values.
+type CounterVec struct {
+	name, help string
+	labelNames []string
+
+	mu     sync.Mutex
+	values map[string]float64
+	labels map[string][]string
+}
+
+// NewCounterVec creates and registers a CounterVec.
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{
+		name: name, help: help, labelNames: labelNames,
+		values: make(map[string]float64), labels: make(map[string][]string),
+	}
+	register(c)
+	return c
+}
+
+// Inc increments the counter for the given label values by 1.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter for the given label values by v.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	key := labelKey(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] += v
+	c.labels[key] = labelValues
+}
+
+func (c *CounterVec) writeTo(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	for _, key := range sortedStringKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %v\n", c.name, formatLabels(c.labelNames, c.labels[key]), c.values[key])
+	}
+}
+
+// Gauge is a metric that can move up or down.
+type Gauge struct {
+	name, help string
+	mu         sync.Mutex
+	value      float64
+}
+
+// NewGauge creates and registers a Gauge.
+func NewGauge(name, help string) *Gauge {
+	g := &Gauge{name: name, help: help}
+	register(g)
+	return g
+}
+
+// Set overwrites the gauge's current value.
+func (g *Gauge) Set(v float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.value = v
+}
+
+// Inc increments the gauge by 1.
+func (g *Gauge) Inc() { g.Add(1) }
+
+// Dec decrements the gauge by 1.
+func (g *Gauge) Dec() { g.Add(-1) }
+
+// Add adds v (which may be negative) to the gauge's current value.
+func (g *Gauge) Add(v float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.value += v
+}
+
+func (g *Gauge) writeTo(w io.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", g.name, g.help, g.name, g.name, g.value)
+}
+
+// defaultBuckets mirrors the Prometheus client library's own defaults,
+// tuned for sub-second request/query latencies.
+var defaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// HistogramVec tracks the distribution of observed values, partitioned by label values.
+type HistogramVec struct {
+	name, help string
+	labelNames []string
+	buckets    []float64
+
+	mu           sync.Mutex
+	bucketCounts map[string][]uint64
+	sums         map[string]float64
+	counts       map[string]uint64
+	labels       map[string][]string
+}
+
+// NewHistogramVec creates and registers a HistogramVec using the default bucket boundaries.
+func NewHistogramVec(name, help string, labelNames ...string) *HistogramVec {
+	h := &HistogramVec{
+		name: name, help: help, labelNames: labelNames, buckets: defaultBuckets,
+		bucketCounts: make(map[string][]uint64), sums: make(map[string]float64),
+		counts: make(map[string]uint64), labels: make(map[string][]string),
+	}
+	register(h)
+	return h
+}
+
+// Observe records v (in the unit the metric is documented in, typically seconds).
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	key := labelKey(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	counts, ok := h.bucketCounts[key]
+	if !ok {
+		counts = make([]uint64, len(h.buckets))
+		h.bucketCounts[key] = counts
+		h.labels[key] = labelValues
+	}
+	for i, bound := range h.buckets {
+		if v <= bound {
+			counts[i]++
+		}
+	}
+	h.sums[key] += v
+	h.counts[key]++
+}
+
+func (h *HistogramVec) writeTo(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	bucketLabelNames := append(append([]string{}, h.labelNames...), "le")
+	for _, key := range sortedUint64Keys(h.counts) {
+		labelValues := h.labels[key]
+		for i, bound := range h.buckets {
+			bucketValues := append(append([]string{}, labelValues...), strconv.FormatFloat(bound, 'g', -1, 64))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabelNames, bucketValues), h.bucketCounts[key][i])
+		}
+		infValues := append(append([]string{}, labelValues...), "+Inf")
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabelNames, infValues), h.counts[key])
+		fmt.Fprintf(w, "%s_sum%s %v\n", h.name, formatLabels(h.labelNames, labelValues), h.sums[key])
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labelNames, labelValues), h.counts[key])
+	}
+}
+
+func sortedStringKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedUint64Keys(m map[string]uint64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+)
+
+// runtimeCollector reports Go runtime statistics (goroutines, heap memory,
+// cumulative GC pause time), read fresh on every scrape rather than polled
+// in the background.
+type runtimeCollector struct{}
+
+// EnableRuntimeMetrics registers the Go runtime collector, so GET /metrics
+// also reports goroutine and memory stats. Gated behind RUNTIME_METRICS=true
+// since most scrapers don't need it and it adds a runtime.ReadMemStats call
+// per scrape.
+func EnableRuntimeMetrics() {
+	register(runtimeCollector{})
+}
+
+func (runtimeCollector) writeTo(w io.Writer) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	fmt.Fprintf(w, "# HELP minirun_go_goroutines Number of goroutines currently running.\n# TYPE minirun_go_goroutines gauge\nminirun_go_goroutines %d\n", runtime.NumGoroutine())
+	fmt.Fprintf(w, "# HELP minirun_go_memstats_alloc_bytes Bytes of allocated heap objects.\n# TYPE minirun_go_memstats_alloc_bytes gauge\nminirun_go_memstats_alloc_bytes %d\n", m.Alloc)
+	fmt.Fprintf(w, "# HELP minirun_go_memstats_sys_bytes Bytes obtained from the OS.\n# TYPE minirun_go_memstats_sys_bytes gauge\nminirun_go_memstats_sys_bytes %d\n", m.Sys)
+	fmt.Fprintf(w, "# HELP minirun_go_gc_duration_seconds_total Cumulative GC pause time.\n# TYPE minirun_go_gc_duration_seconds_total counter\nminirun_go_gc_duration_seconds_total %v\n", float64(m.PauseTotalNs)/1e9)
+}
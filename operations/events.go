@@ -0,0 +1,72 @@
+package operations
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType is the lifecycle event a container transitioned through.
+type EventType string
+
+const (
+	EventCreate  EventType = "create"
+	EventStart   EventType = "start"
+	EventDie     EventType = "die"
+	EventDestroy EventType = "destroy"
+)
+
+// Event is published to the EventBus on each container lifecycle
+// transition and relayed to subscribers (e.g. GET /events) verbatim.
+type Event struct {
+	Type      EventType `json:"type"`
+	Container string    `json:"container"`
+	Time      time.Time `json:"time"`
+	ExitCode  *int      `json:"exit_code,omitempty"`
+}
+
+// EventBus fans out container lifecycle events to any number of subscribers.
+// An event is dropped for any subscriber that has fallen behind (a full
+// channel) rather than blocking publishers; the subscriber stays registered.
+type EventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewEventBus constructs an empty EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[chan Event]struct{})}
+}
+
+// Subscribe registers a new listener and returns its channel plus an
+// Unsubscribe func the caller must call when done (typically via defer on
+// the handler that called Subscribe).
+func (b *EventBus) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, 32)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+		b.mu.Unlock()
+	}
+	return ch, unsubscribe
+}
+
+// Publish sends evt to every current subscriber, dropping it for any
+// subscriber whose buffer is full instead of blocking.
+func (b *EventBus) Publish(evt Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- evt:
+		default:
+			// Subscriber too slow; drop rather than stall the publisher.
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Package operations implements an LXD-style async operations model: a
+// long-running action (starting a container, pulling a rootfs, ...) is
+// represented as an Operation that transitions pending -> running ->
+// success/failure while callers poll or subscribe to its state instead of
+// blocking the HTTP request that kicked it off.
+package operations
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Raafay-Qureshi/minirun-container-runtime/metrics"
+)
+
+// Class describes what kind of caller is driving an Operation, mirroring
+// LXD's distinction between synchronous polling, websocket-driven, and
+// token-based (fire-and-forget) operations.
+type Class string
+
+const (
+	ClassTask      Class = "task"
+	ClassWebsocket Class = "websocket"
+	ClassToken     Class = "token"
+)
+
+// Status is the lifecycle state of an Operation.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusRunning Status = "running"
+	StatusSuccess Status = "success"
+	StatusFailure Status = "failure"
+)
+
+// Operation tracks a single async action from creation through completion.
+// Fields are read under Manager's lock via the accessor methods below; the
+// struct itself should not be copied or mutated by callers.
+type Operation struct {
+	ID        string
+	Class     Class
+	Resources map[string]string // e.g. {"containers": "<name>"}
+	Status    Status
+	StartedAt time.Time
+	UpdatedAt time.Time
+	Err       string
+	Result    interface{}
+}
+
+// snapshot returns a copy of o safe to hand to callers outside the lock.
+func (o *Operation) snapshot() Operation {
+	return *o
+}
+
+// Manager tracks in-flight and recently-completed operations. Completed
+// operations are retained for Retention so late pollers can still fetch a
+// result, then swept by a background goroutine.
+type Manager struct {
+	mu        sync.RWMutex
+	ops       map[string]*Operation
+	nextID    uint64
+	Retention time.Duration
+}
+
+// DefaultRetention matches the "at least N minutes" requirement for late
+// pollers to retrieve a finished operation's result.
+const DefaultRetention = 10 * time.Minute
+
+// NewManager constructs an empty Manager with DefaultRetention.
+func NewManager() *Manager {
+	return &Manager{
+		ops:       make(map[string]*Operation),
+		Retention: DefaultRetention,
+	}
+}
+
+// Run starts fn in a goroutine, tracked as a new Operation of the given
+// class with the given resources, and returns immediately with the
+// operation's (pending) snapshot. fn's return value becomes Operation.Result
+// on success, or its error becomes Operation.Err on failure.
+func (m *Manager) Run(class Class, resources map[string]string, fn func(ctx context.Context) (interface{}, error)) Operation {
+	m.mu.Lock()
+	m.nextID++
+	id := fmt.Sprintf("op-%d", m.nextID)
+	op := &Operation{
+		ID:        id,
+		Class:     class,
+		Resources: resources,
+		Status:    StatusPending,
+		StartedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	m.ops[id] = op
+	snap := op.snapshot()
+	m.mu.Unlock()
+
+	metrics.OperationsInflight.Inc()
+	go m.execute(op, fn)
+
+	return snap
+}
+
+func (m *Manager) execute(op *Operation, fn func(ctx context.Context) (interface{}, error)) {
+	defer metrics.OperationsInflight.Dec()
+	m.setStatus(op, StatusRunning, nil, "")
+
+	result, err := fn(context.Background())
+	if err != nil {
+		m.setStatus(op, StatusFailure, nil, err.Error())
+		return
+	}
+	m.setStatus(op, StatusSuccess, result, "")
+}
+
+func (m *Manager) setStatus(op *Operation, status Status, result interface{}, errMsg string) {
+	m.mu.Lock()
+	op.Status = status
+	op.Result = result
+	op.Err = errMsg
+	op.UpdatedAt = time.Now()
+	m.mu.Unlock()
+}
+
+// Get returns a snapshot of the operation with the given ID.
+func (m *Manager) Get(id string) (Operation, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	op, ok := m.ops[id]
+	if !ok {
+		return Operation{}, false
+	}
+	return op.snapshot(), true
+}
+
+// Sweep removes operations that finished more than Retention ago. Callers
+// should run this periodically (e.g. via time.Ticker) since Manager never
+// does so on its own.
+func (m *Manager) Sweep() {
+	cutoff := time.Now().Add(-m.Retention)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, op := range m.ops {
+		if op.Status == StatusPending || op.Status == StatusRunning {
+			continue
+		}
+		if op.UpdatedAt.Before(cutoff) {
+			delete(m.ops, id)
+		}
+	}
+}
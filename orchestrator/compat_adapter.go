@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"syscall"
+
+	"github.com/Raafay-Qureshi/minirun-container-runtime/api/compat"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/errdefs"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/operations"
+)
+
+// compatBackend adapts the orchestrator's container store to compat.Backend
+// so the Docker-compatible API surface can be mounted on the same router
+// without api/compat depending on package main.
+type compatBackend struct{}
+
+func (compatBackend) ListContainers() ([]compat.ContainerRecord, error) {
+	containers, err := listAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]compat.ContainerRecord, len(containers))
+	for i, c := range containers {
+		records[i] = toContainerRecord(c)
+	}
+	return records, nil
+}
+
+func (compatBackend) GetContainer(name string) (*compat.ContainerRecord, error) {
+	container, err := loadContainer(name)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, compat.ErrNotFound
+		}
+		return nil, err
+	}
+	record := toContainerRecord(*container)
+	return &record, nil
+}
+
+func (compatBackend) CreateContainer(name, rootfs, command string) (*compat.ContainerRecord, error) {
+	container, err := createContainer(name, rootfs, command, nil)
+	if err != nil {
+		if errdefs.IsConflict(err) {
+			return nil, compat.ErrConflict
+		}
+		return nil, err
+	}
+	record := toContainerRecord(*container)
+	return &record, nil
+}
+
+func (compatBackend) DeleteContainer(name string) error {
+	if err := removeContainerByName(name); err != nil {
+		if errdefs.IsNotFound(err) {
+			return compat.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// StartContainer launches RuntimeBinary through the same async operation
+// machinery as POST /containers/{name}/start, but discards the operation
+// handle since Docker's start endpoint responds with no body.
+func (compatBackend) StartContainer(name string) error {
+	container, err := loadContainer(name)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return compat.ErrNotFound
+		}
+		return err
+	}
+	opManager.Run(operations.ClassTask, map[string]string{"containers": name}, func(ctx context.Context) (interface{}, error) {
+		return runContainerProcess(container)
+	})
+	return nil
+}
+
+// StopContainer sends SIGTERM to the tracked runtime process.
+func (compatBackend) StopContainer(name string) error {
+	return signalContainer(name, syscall.SIGTERM)
+}
+
+// KillContainer sends SIGKILL to the tracked runtime process.
+func (compatBackend) KillContainer(name string) error {
+	return signalContainer(name, syscall.SIGKILL)
+}
+
+func signalContainer(name string, sig syscall.Signal) error {
+	container, err := loadContainer(name)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return compat.ErrNotFound
+		}
+		return err
+	}
+	if container.Pid == 0 {
+		return errdefs.InvalidParameterMsg("container '" + name + "' is not running")
+	}
+	return syscall.Kill(container.Pid, sig)
+}
+
+func toContainerRecord(c Container) compat.ContainerRecord {
+	return compat.ContainerRecord{
+		Name:      c.Name,
+		RootFS:    c.RootFS,
+		Command:   c.Command,
+		Status:    c.Status,
+		CreatedAt: c.CreatedAt,
+	}
+}
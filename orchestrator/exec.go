@@ -0,0 +1,327 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/creack/pty"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"github.com/Raafay-Qureshi/minirun-container-runtime/errdefs"
+)
+
+// execInstance tracks one POST /containers/{name}/exec invocation until its
+// single-use token is redeemed by GET /exec/{id}/ws, or forever if it never is.
+type execInstance struct {
+	ID        string
+	Container string
+	Cmd       []string
+	TTY       bool
+	Token     string
+	used      bool
+}
+
+var (
+	execMu        sync.Mutex
+	execInstances = make(map[string]*execInstance)
+)
+
+// ExecCreateRequest is the JSON body for POST /containers/{name}/exec.
+type ExecCreateRequest struct {
+	Cmd []string `json:"Cmd"`
+	Tty bool     `json:"Tty"`
+}
+
+// ExecCreateResponse is returned by POST /containers/{name}/exec. Token must
+// be passed as a query param to GET /exec/{id}/ws; it's the only credential
+// that endpoint checks, since a browser terminal can't attach custom headers
+// to the WebSocket handshake.
+type ExecCreateResponse struct {
+	Id    string `json:"Id"`
+	Token string `json:"Token"`
+}
+
+// ExecCreateHandler registers a pending exec instance for a container
+// (POST /containers/{name}/exec). The instance is consumed the first time
+// its token is redeemed at GET /exec/{id}/ws.
+func ExecCreateHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	var req ExecCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ErrorResponse(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Cmd) == 0 {
+		ErrorResponse(w, "Cmd is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := loadContainer(name); err != nil {
+		HandleErr(w, err)
+		return
+	}
+
+	id, err := randomToken()
+	if err != nil {
+		ErrorResponse(w, "Failed to create exec instance: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	token, err := randomToken()
+	if err != nil {
+		ErrorResponse(w, "Failed to create exec instance: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	inst := &execInstance{ID: id, Container: name, Cmd: req.Cmd, TTY: req.Tty, Token: token}
+	execMu.Lock()
+	execInstances[id] = inst
+	execMu.Unlock()
+
+	SuccessResponse(w, "Exec instance created", ExecCreateResponse{Id: id, Token: token})
+}
+
+// randomToken returns a 32-character random hex string, suitable for both
+// exec instance IDs and their single-use auth tokens.
+func randomToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// takeExecInstance redeems id's single-use token, removing the instance so
+// the same URL can't be attached to twice.
+func takeExecInstance(id, token string) (*execInstance, error) {
+	execMu.Lock()
+	defer execMu.Unlock()
+
+	inst, ok := execInstances[id]
+	if !ok {
+		return nil, errdefs.NotFoundMsg(fmt.Sprintf("exec instance '%s' not found", id))
+	}
+	if inst.used || token == "" || inst.Token != token {
+		return nil, errdefs.ForbiddenMsg("invalid or already-used exec token")
+	}
+	inst.used = true
+	delete(execInstances, id)
+	return inst, nil
+}
+
+var execUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// resizeMessage is sent as a WebSocket text frame by TTY clients (e.g.
+// xterm.js) whenever the terminal window is resized.
+type resizeMessage struct {
+	Width  uint16 `json:"Width"`
+	Height uint16 `json:"Height"`
+}
+
+// ExecWSHandler upgrades to a WebSocket and proxies the exec'd process's
+// I/O over it (GET /exec/{id}/ws?token=...). TTY instances get a raw
+// passthrough stream plus TIOCSWINSZ resize support; non-TTY instances are
+// framed with Docker's stdcopy header so stdout and stderr stay demultiplexable.
+func ExecWSHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	token := r.URL.Query().Get("token")
+
+	inst, err := takeExecInstance(id, token)
+	if err != nil {
+		HandleErr(w, err)
+		return
+	}
+
+	conn, err := execUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Warning: exec websocket upgrade failed for '%s': %v", inst.Container, err)
+		return
+	}
+	defer conn.Close()
+
+	container, err := loadContainer(inst.Container)
+	if err != nil {
+		conn.WriteMessage(websocket.TextMessage, []byte("failed to load container: "+err.Error()))
+		return
+	}
+	if container.Pid == 0 {
+		conn.WriteMessage(websocket.TextMessage, []byte("container '"+container.Name+"' is not running"))
+		return
+	}
+
+	cmd := execCommand(container, inst.Cmd)
+
+	if inst.TTY {
+		runExecTTY(conn, cmd)
+	} else {
+		runExecPipes(conn, cmd)
+	}
+}
+
+// execCommand builds the RuntimeBinary invocation that runs cmdAndArgs
+// inside container's existing namespaces (identified by its tracked pid),
+// the same binary StartContainerHandler uses to launch the container's main
+// process, rather than exec'ing the command directly on the host.
+func execCommand(container *Container, cmdAndArgs []string) *exec.Cmd {
+	args := append([]string{"exec", container.Name, strconv.Itoa(container.Pid)}, cmdAndArgs...)
+	return exec.Command(RuntimeBinary, args...)
+}
+
+// runExecTTY spawns cmd behind a PTY and shuttles raw bytes between the
+// PTY master and the WebSocket in both directions. A text frame that decodes
+// as a resize message with a non-zero Width or Height resizes the PTY; every
+// other frame is written to the PTY as input.
+func runExecTTY(conn *websocket.Conn, cmd *exec.Cmd) {
+	master, err := pty.Start(cmd)
+	if err != nil {
+		conn.WriteMessage(websocket.TextMessage, []byte("failed to start pty: "+err.Error()))
+		return
+	}
+	defer master.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 4096)
+		for {
+			n, err := master.Read(buf)
+			if n > 0 {
+				if werr := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		if msgType == websocket.TextMessage {
+			var resize resizeMessage
+			if err := json.Unmarshal(data, &resize); err == nil && (resize.Width > 0 || resize.Height > 0) {
+				setWinsize(master, resize.Width, resize.Height)
+				continue
+			}
+		}
+		master.Write(data)
+	}
+
+	cmd.Process.Kill()
+	<-done
+	cmd.Wait()
+}
+
+// winsize mirrors struct winsize from <sys/ioctl.h>, as TIOCSWINSZ expects it.
+type winsize struct {
+	rows, cols, x, y uint16
+}
+
+// setWinsize applies a TIOCSWINSZ ioctl to f (a PTY master), so the child
+// process sees the new terminal dimensions when it next queries the terminal size.
+func setWinsize(f *os.File, width, height uint16) {
+	ws := winsize{rows: height, cols: width}
+	syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TIOCSWINSZ, uintptr(unsafe.Pointer(&ws)))
+}
+
+const (
+	stdcopyStdout byte = 1
+	stdcopyStderr byte = 2
+)
+
+// stdcopyFrame wraps p in Docker's 8-byte stdcopy header (stream id,
+// 3 reserved bytes, then a big-endian uint32 length) so a client can
+// demultiplex stdout from stderr on a single connection.
+func stdcopyFrame(stream byte, p []byte) []byte {
+	frame := make([]byte, 8+len(p))
+	frame[0] = stream
+	binary.BigEndian.PutUint32(frame[4:8], uint32(len(p)))
+	copy(frame[8:], p)
+	return frame
+}
+
+// runExecPipes spawns cmd with plain stdio pipes (no PTY), framing stdout
+// and stderr with stdcopyFrame and forwarding WebSocket input to stdin.
+func runExecPipes(conn *websocket.Conn, cmd *exec.Cmd) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		conn.WriteMessage(websocket.TextMessage, []byte("failed to attach stdin: "+err.Error()))
+		return
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		conn.WriteMessage(websocket.TextMessage, []byte("failed to attach stdout: "+err.Error()))
+		return
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		conn.WriteMessage(websocket.TextMessage, []byte("failed to attach stderr: "+err.Error()))
+		return
+	}
+
+	if err := cmd.Start(); err != nil {
+		conn.WriteMessage(websocket.TextMessage, []byte("failed to start process: "+err.Error()))
+		return
+	}
+
+	var writeMu sync.Mutex
+	relay := func(stream byte, r io.Reader) {
+		buf := make([]byte, 4096)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				writeMu.Lock()
+				wErr := conn.WriteMessage(websocket.BinaryMessage, stdcopyFrame(stream, buf[:n]))
+				writeMu.Unlock()
+				if wErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); relay(stdcopyStdout, stdout) }()
+	go func() { defer wg.Done(); relay(stdcopyStderr, stderr) }()
+
+	go func() {
+		for {
+			msgType, data, err := conn.ReadMessage()
+			if err != nil {
+				stdin.Close()
+				return
+			}
+			if msgType == websocket.BinaryMessage || msgType == websocket.TextMessage {
+				stdin.Write(data)
+			}
+		}
+	}()
+
+	wg.Wait()
+	cmd.Wait()
+}
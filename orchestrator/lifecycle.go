@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Raafay-Qureshi/minirun-container-runtime/metrics"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/operations"
+)
+
+// opManager tracks async operations (container starts, ...) so HTTP handlers
+// can return immediately while the runtime process runs in the background.
+var opManager = operations.NewManager()
+
+// eventBus fans out container lifecycle events to GET /events subscribers.
+var eventBus = operations.NewEventBus()
+
+// StartContainerHandler launches RuntimeBinary for the named container and
+// returns 202 Accepted with a Location header pointing at the resulting
+// operation, rather than blocking the request on a potentially long-lived
+// process (POST /containers/{name}/start).
+func StartContainerHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	if name == "" {
+		ErrorResponse(w, "Container name is required", http.StatusBadRequest)
+		return
+	}
+
+	container, err := loadContainer(name)
+	if err != nil {
+		HandleErr(w, err)
+		return
+	}
+
+	op := opManager.Run(operations.ClassTask, map[string]string{"containers": name}, func(ctx context.Context) (interface{}, error) {
+		return runContainerProcess(container)
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/operations/"+op.ID)
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Message: "Container start initiated", Data: op})
+}
+
+// runContainerProcess invokes RuntimeBinary via os/exec, records the pid once
+// it's running, then blocks (inside the operation's goroutine, not the HTTP
+// request) until the process exits and records its exit code.
+func runContainerProcess(container *Container) (interface{}, error) {
+	cmd := exec.Command(RuntimeBinary, container.Name, container.RootFS, container.Command)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start runtime binary: %w", err)
+	}
+
+	pid := cmd.Process.Pid
+	startedAt := time.Now()
+	if err := markContainerStarted(container.Name, pid, startedAt); err != nil {
+		log.Printf("Warning: failed to record start of '%s': %v", container.Name, err)
+	}
+	eventBus.Publish(operations.Event{Type: operations.EventStart, Container: container.Name, Time: startedAt})
+
+	waitErr := cmd.Wait()
+	exitCode := 0
+	if waitErr != nil {
+		if exitErr, ok := waitErr.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		} else {
+			exitCode = -1
+		}
+	}
+
+	finishedAt := time.Now()
+	if err := markContainerFinished(container.Name, exitCode, finishedAt); err != nil {
+		log.Printf("Warning: failed to record exit of '%s': %v", container.Name, err)
+	}
+	eventBus.Publish(operations.Event{Type: operations.EventDie, Container: container.Name, Time: finishedAt, ExitCode: &exitCode})
+
+	if waitErr != nil {
+		return nil, fmt.Errorf("container process exited with error: %w", waitErr)
+	}
+	return map[string]interface{}{"pid": pid, "exit_code": exitCode}, nil
+}
+
+// markContainerStarted persists the runtime process's pid and start time.
+func markContainerStarted(name string, pid int, startedAt time.Time) error {
+	if err := store.UpdateContainerLifecycle(name, "running", pid, 0, &startedAt, nil); err != nil {
+		return err
+	}
+	metrics.ContainersTotal.Inc("running")
+	return nil
+}
+
+// markContainerFinished persists the runtime process's exit code and finish time.
+func markContainerFinished(name string, exitCode int, finishedAt time.Time) error {
+	if err := store.UpdateContainerLifecycle(name, "stopped", 0, exitCode, nil, &finishedAt); err != nil {
+		return err
+	}
+	metrics.ContainersTotal.Inc("stopped")
+	return nil
+}
+
+// OperationStatusHandler returns the current state of an async operation
+// (GET /operations/{id}), so clients that started a container can poll
+// instead of (or in addition to) subscribing to GET /events.
+func OperationStatusHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	op, ok := opManager.Get(id)
+	if !ok {
+		ErrorResponse(w, "Operation '"+id+"' not found", http.StatusNotFound)
+		return
+	}
+	SuccessResponse(w, "Operation found", op)
+}
+
+// EventsHandler streams container lifecycle events as Server-Sent Events
+// (GET /events) until the client disconnects.
+func EventsHandler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		ErrorResponse(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch, unsubscribe := eventBus.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case evt, open := <-ch:
+			if !open {
+				return
+			}
+			data, err := json.Marshal(evt)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", evt.Type, data)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
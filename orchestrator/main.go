@@ -1,27 +1,41 @@
 package main
 
 import (
+	"bufio"          // Buffered I/O for http.Hijacker
+	"context"        // Per-request values (request ID)
 	"encoding/json"  // JSON encoding/decoding for API responses
 	"fmt"            // Formatted I/O
 	"log"            // Logging
+	"log/slog"       // Structured logging
+	"net"            // Hijacked connections for http.Hijacker
 	"net/http"       // HTTP server and client
 	"os"             // Operating system functions
-	"path/filepath"  // File path manipulation
+	"strconv"        // Query parameter parsing
 	"time"           // Time and duration handling
 
 	"github.com/gorilla/mux"  // HTTP router with URL parameters
+
+	"github.com/Raafay-Qureshi/minirun-container-runtime/api/compat"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/errdefs"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/metrics"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/operations"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/storage"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/storage/boltdb"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/storage/inmemory"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/storage/postgres"
 )
 
-// Global database instance (nil if using file storage)
-var db *Database
-var useDatabase bool  // true = PostgreSQL, false = JSON files
+// store is the active persistence backend, selected at startup via the
+// STORAGE_DRIVER env var. All handlers go through it instead of branching on
+// a storage-kind flag themselves.
+var store storage.Store
 
 // Server configuration constants
 const (
 	ProjectRoot     = "/home/raafayqureshi/container-project"
-	ContainersDir   = ProjectRoot + "/containers"  // JSON storage directory
 	RuntimeBinary   = ProjectRoot + "/bin/container_runtime"  // C runtime binary
 	DefaultRootFS   = ProjectRoot + "/myroot"  // Default container root filesystem
+	DefaultBoltPath = ProjectRoot + "/minirun.db"  // Default BoltDB file location
 	ServerPort      = "8080"   // HTTP port
 	ServerPortTLS   = "8443"   // HTTPS port
 	ServerVersion   = "1.0.0"
@@ -29,20 +43,17 @@ const (
 	DefaultKeyPath  = "/etc/minirun/key.pem"   // TLS private key location
 )
 
-// Container represents container configuration (stored in DB or JSON file)
-type Container struct {
-	Name      string    `json:"name"`       // Unique container name
-	RootFS    string    `json:"rootfs"`     // Path to root filesystem
-	Command   string    `json:"command"`    // Command to execute
-	Status    string    `json:"status"`     // created/running/stopped
-	CreatedAt time.Time `json:"created_at"` // Creation timestamp
-}
+// Container represents container configuration. It's a type alias for
+// storage.Container so handlers, the compat adapter, and the lifecycle
+// manager all share the storage layer's definition.
+type Container = storage.Container
 
 // CreateRequest is the JSON body for POST /containers
 type CreateRequest struct {
-	Name    string `json:"name"`                    // Required: container name
-	RootFS  string `json:"rootfs,omitempty"`        // Optional: defaults to DefaultRootFS
-	Command string `json:"command,omitempty"`       // Optional: defaults to /bin/bash
+	Name    string            `json:"name"`                 // Required: container name
+	RootFS  string            `json:"rootfs,omitempty"`      // Optional: defaults to DefaultRootFS
+	Command string            `json:"command,omitempty"`     // Optional: defaults to /bin/bash
+	Labels  map[string]string `json:"labels,omitempty"`      // Optional: arbitrary key/value metadata
 }
 
 // APIResponse is the standard JSON response format
@@ -60,6 +71,26 @@ func ErrorResponse(w http.ResponseWriter, message string, code int) {
 	json.NewEncoder(w).Encode(APIResponse{Success: false, Error: message})
 }
 
+// HandleErr inspects err's errdefs kind to choose the HTTP status code,
+// instead of comparing err.Error() strings. Unrecognized errors fall back to
+// 500 so a forgotten kind never silently becomes the wrong status.
+func HandleErr(w http.ResponseWriter, err error) {
+	switch {
+	case errdefs.IsNotFound(err):
+		ErrorResponse(w, err.Error(), http.StatusNotFound)
+	case errdefs.IsConflict(err):
+		ErrorResponse(w, err.Error(), http.StatusConflict)
+	case errdefs.IsInvalidParameter(err):
+		ErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errdefs.IsForbidden(err):
+		ErrorResponse(w, err.Error(), http.StatusForbidden)
+	case errdefs.IsUnavailable(err):
+		ErrorResponse(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		ErrorResponse(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // SuccessResponse sends JSON success with data payload
 func SuccessResponse(w http.ResponseWriter, message string, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -77,269 +108,255 @@ func HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	SuccessResponse(w, "Service is healthy", health)
 }
 
+// createContainer builds and persists a container record via the active
+// store, returning an errdefs.Conflict error if the name is already taken.
+// Shared by CreateContainerHandler and the api/compat adapter.
+func createContainer(name, rootfs, command string, labels map[string]string) (*Container, error) {
+	if rootfs == "" {
+		rootfs = DefaultRootFS
+	}
+	if command == "" {
+		command = "/bin/bash"
+	}
+
+	container := Container{
+		Name: name, RootFS: rootfs, Command: command,
+		Status: "created", CreatedAt: time.Now(), Labels: labels,
+	}
+
+	if err := store.CreateContainer(&container); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Container '%s' created successfully", name)
+	metrics.ContainersTotal.Inc(container.Status)
+	eventBus.Publish(operations.Event{Type: operations.EventCreate, Container: name, Time: time.Now()})
+	return &container, nil
+}
+
 // CreateContainerHandler creates new container config (POST /containers)
 func CreateContainerHandler(w http.ResponseWriter, r *http.Request) {
 	var req CreateRequest
-	
+
 	// Parse and validate JSON request body
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		ErrorResponse(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	
+
 	if req.Name == "" {
 		ErrorResponse(w, "Container name is required", http.StatusBadRequest)
 		return
 	}
-	
-	// Apply defaults for optional fields
-	if req.RootFS == "" {
-		req.RootFS = DefaultRootFS
+
+	container, err := createContainer(req.Name, req.RootFS, req.Command, req.Labels)
+	if err != nil {
+		HandleErr(w, err)
+		return
 	}
-	if req.Command == "" {
-		req.Command = "/bin/bash"
+
+	SuccessResponse(w, "Container created successfully", container)
+}
+
+// listAllContainers loads every container from the active store.
+func listAllContainers() ([]Container, error) {
+	return store.ListContainers()
+}
+
+// ListContainersHandler returns containers (GET /containers), optionally
+// narrowed by a Docker-style `?filters={"status":["running"],...}` query
+// param and paginated with `?limit=N`. The pre-pagination match count is
+// reported via X-Total-Count, and X-Next-Cursor carries the last returned
+// container's name so a caller can page further with `?filters={"before":"<cursor>"}`.
+func ListContainersHandler(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r)
+	if err != nil {
+		ErrorResponse(w, "Invalid filters: "+err.Error(), http.StatusBadRequest)
+		return
 	}
-	
-	// Build container config with current timestamp
-	container := Container{
-		Name: req.Name, RootFS: req.RootFS, Command: req.Command,
-		Status: "created", CreatedAt: time.Now(),
+
+	result, err := store.ListContainersFiltered(opts)
+	if err != nil {
+		ErrorResponse(w, "Failed to list containers: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
-	
-	// Save to PostgreSQL or JSON file (depends on useDatabase flag)
-	if useDatabase {
-		// Database path: check existence then insert
-		exists, err := db.ContainerExists(req.Name)
-		if err != nil {
-			ErrorResponse(w, "Database error: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if exists {
-			ErrorResponse(w, "Container '"+req.Name+"' already exists", http.StatusConflict)
-			return
-		}
-		
-		if err := db.CreateContainer(&container); err != nil {
-			ErrorResponse(w, "Failed to create container: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-	} else {
-		// File path: check existence then write JSON
-		configPath := filepath.Join(ContainersDir, req.Name+".json")
-		if _, err := os.Stat(configPath); err == nil {
-			ErrorResponse(w, "Container '"+req.Name+"' already exists", http.StatusConflict)
-			return
-		}
-		
-		if err := os.MkdirAll(ContainersDir, 0755); err != nil {
-			ErrorResponse(w, "Failed to create containers directory: "+err.Error(), http.StatusInternalServerError)
-			return
+
+	w.Header().Set("X-Total-Count", fmt.Sprintf("%d", result.Total))
+	if len(result.Containers) < result.Total {
+		w.Header().Set("X-Next-Cursor", result.Containers[len(result.Containers)-1].Name)
+	}
+
+	SuccessResponse(w, fmt.Sprintf("Found %d container(s)", len(result.Containers)), result.Containers)
+}
+
+// parseListOptions parses GET /containers' `filters` and `limit` query
+// params into a storage.ListOptions. `filters` is a JSON object of the form
+// {"status":["running"],"name":["web-"],"label":["env=prod"],"since":"<name>","before":"<name>"},
+// matching Docker's own filters encoding.
+func parseListOptions(r *http.Request) (storage.ListOptions, error) {
+	var opts storage.ListOptions
+
+	if raw := r.URL.Query().Get("filters"); raw != "" {
+		var parsed struct {
+			Status []string `json:"status"`
+			Name   []string `json:"name"`
+			Label  []string `json:"label"`
+			Since  string   `json:"since"`
+			Before string   `json:"before"`
 		}
-		
-		configData, err := json.MarshalIndent(container, "", "  ")
-		if err != nil {
-			ErrorResponse(w, "Failed to serialize container config: "+err.Error(), http.StatusInternalServerError)
-			return
+		if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+			return opts, err
 		}
-		
-		if err := os.WriteFile(configPath, configData, 0644); err != nil {
-			ErrorResponse(w, "Failed to save container config: "+err.Error(), http.StatusInternalServerError)
-			return
+		opts.Filters = storage.ListFilters{
+			Status: parsed.Status,
+			Name:   parsed.Name,
+			Label:  parsed.Label,
+			Since:  parsed.Since,
+			Before: parsed.Before,
 		}
 	}
-	
-	log.Printf("Container '%s' created successfully", req.Name)
-	SuccessResponse(w, "Container created successfully", container)
-}
 
-// ListContainersHandler returns all containers (GET /containers)
-func ListContainersHandler(w http.ResponseWriter, r *http.Request) {
-	var containers []Container
-	var err error
-	
-	if useDatabase {
-		// Database path: query all rows
-		containers, err = db.ListContainers()
-		if err != nil {
-			ErrorResponse(w, "Failed to list containers: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-	} else {
-		// File path: read all JSON files from directory
-		entries, err := os.ReadDir(ContainersDir)
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
 		if err != nil {
-			if os.IsNotExist(err) {
-				SuccessResponse(w, "No containers found", []Container{})
-				return
-			}
-			ErrorResponse(w, "Failed to read containers directory: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-		
-		containers = []Container{}
-		
-		// Load each container configuration
-		for _, entry := range entries {
-			if filepath.Ext(entry.Name()) != ".json" {
-				continue
-			}
-			
-			configPath := filepath.Join(ContainersDir, entry.Name())
-			data, err := os.ReadFile(configPath)
-			if err != nil {
-				log.Printf("Warning: Failed to read %s: %v", entry.Name(), err)
-				continue
-			}
-			
-			var container Container
-			if err := json.Unmarshal(data, &container); err != nil {
-				log.Printf("Warning: Failed to parse %s: %v", entry.Name(), err)
-				continue
-			}
-			
-			containers = append(containers, container)
+			return opts, fmt.Errorf("limit must be an integer: %w", err)
 		}
+		opts.Limit = limit
 	}
-	
-	SuccessResponse(w, fmt.Sprintf("Found %d container(s)", len(containers)), containers)
+
+	return opts, nil
+}
+
+// loadContainer fetches a single container by name from the active store,
+// returning an errdefs.NotFound error if it doesn't exist.
+func loadContainer(name string) (*Container, error) {
+	return store.GetContainer(name)
 }
 
 // GetContainerHandler returns container info (GET /containers/{name})
 func GetContainerHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)  // Extract URL parameters
 	name := vars["name"]
-	
+
 	if name == "" {
 		ErrorResponse(w, "Container name is required", http.StatusBadRequest)
 		return
 	}
-	
-	var container *Container
-	var err error
-	
-	if useDatabase {
-		// Database path: SELECT by name
-		container, err = db.GetContainer(name)
-		if err != nil {
-			if err.Error() == "container not found" {
-				ErrorResponse(w, "Container '"+name+"' not found", http.StatusNotFound)
-				return
-			}
-			ErrorResponse(w, "Failed to get container: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-	} else {
-		// Load container configuration from file
-		configPath := filepath.Join(ContainersDir, name+".json")
-		data, err := os.ReadFile(configPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				ErrorResponse(w, "Container '"+name+"' not found", http.StatusNotFound)
-				return
-			}
-			ErrorResponse(w, "Failed to read container config: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-		
-		var c Container
-		if err := json.Unmarshal(data, &c); err != nil {
-			ErrorResponse(w, "Failed to parse container config: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-		container = &c
+
+	container, err := loadContainer(name)
+	if err != nil {
+		HandleErr(w, err)
+		return
 	}
-	
+
 	SuccessResponse(w, "Container found", container)
 }
 
+// removeContainerByName deletes a container by name from the active store,
+// returning an errdefs.NotFound error if it doesn't exist.
+func removeContainerByName(name string) error {
+	if err := store.DeleteContainer(name); err != nil {
+		return err
+	}
+	metrics.ContainersTotal.Inc("deleted")
+	return nil
+}
+
 // DeleteContainerHandler removes container (DELETE /containers/{name})
 func DeleteContainerHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	name := vars["name"]
-	
+
 	if name == "" {
 		ErrorResponse(w, "Container name is required", http.StatusBadRequest)
 		return
 	}
-	
-	if useDatabase {
-		// Database path: DELETE query
-		if err := db.DeleteContainer(name); err != nil {
-			if err.Error() == "container not found" {
-				ErrorResponse(w, "Container '"+name+"' not found", http.StatusNotFound)
-				return
-			}
-			ErrorResponse(w, "Failed to delete container: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-	} else {
-		// Check if container exists
-		configPath := filepath.Join(ContainersDir, name+".json")
-		if _, err := os.Stat(configPath); os.IsNotExist(err) {
-			ErrorResponse(w, "Container '"+name+"' not found", http.StatusNotFound)
-			return
-		}
-		
-		// Delete container configuration file
-		if err := os.Remove(configPath); err != nil {
-			ErrorResponse(w, "Failed to delete container: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+
+	if err := removeContainerByName(name); err != nil {
+		HandleErr(w, err)
+		return
 	}
-	
+
 	log.Printf("Container '%s' deleted successfully", name)
+	eventBus.Publish(operations.Event{Type: operations.EventDestroy, Container: name, Time: time.Now()})
 	SuccessResponse(w, "Container deleted successfully", map[string]string{"name": name})
 }
 
-// StartContainerHandler provides container start instructions (POST /containers/{name}/start)
-func StartContainerHandler(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	name := vars["name"]
-	
-	if name == "" {
-		ErrorResponse(w, "Container name is required", http.StatusBadRequest)
-		return
-	}
-	
-	// Load container configuration
-	configPath := filepath.Join(ContainersDir, name+".json")
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			ErrorResponse(w, "Container '"+name+"' not found", http.StatusNotFound)
-			return
-		}
-		ErrorResponse(w, "Failed to read container config: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-	
-	var container Container
-	if err := json.Unmarshal(data, &container); err != nil {
-		ErrorResponse(w, "Failed to parse container config: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-	
-	// Interactive container start requires terminal I/O (not suitable for REST API)
-	// Return CLI command instead for user to execute
-	startInfo := map[string]string{
-		"message": "Container start requires interactive terminal",
-		"command": fmt.Sprintf("sudo %s %s %s %s", RuntimeBinary, container.Name, container.RootFS, container.Command),
-		"cli":     fmt.Sprintf("./minirun start %s", container.Name),
-	}
-	
-	SuccessResponse(w, "Container start information", startInfo)
+// requestIDKey is the context key the request ID generated by
+// LoggingMiddleware is stored under, so handlers further down the chain can
+// recover it via RequestIDFromContext.
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+// RequestIDFromContext returns the request ID LoggingMiddleware attached to
+// ctx, or "" if ctx didn't come from a request LoggingMiddleware handled.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// statusRecorder wraps http.ResponseWriter to remember the status code a
+// handler wrote, since http.ResponseWriter itself doesn't expose it and
+// LoggingMiddleware needs it after next.ServeHTTP returns. It forwards
+// Hijack and Flush so it doesn't break ExecWSHandler's websocket upgrade or
+// EventsHandler's SSE streaming, both of which type-assert for them.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return rec.ResponseWriter.(http.Hijacker).Hijack()
 }
 
-// LoggingMiddleware logs HTTP method, URI, client IP, and duration
+func (rec *statusRecorder) Flush() {
+	rec.ResponseWriter.(http.Flusher).Flush()
+}
+
+// LoggingMiddleware generates a per-request ID (echoed back via
+// X-Request-ID and reachable downstream through RequestIDFromContext), logs
+// the request as structured slog output, and records it against the
+// minirun_http_requests_total / minirun_http_request_duration_seconds metrics.
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := randomToken()
+		if err != nil {
+			id = "unknown"
+		}
+		w.Header().Set("X-Request-ID", id)
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey, id))
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
 		start := time.Now()
-		log.Printf("[%s] %s %s", r.Method, r.RequestURI, r.RemoteAddr)
-		next.ServeHTTP(w, r)
-		log.Printf("[%s] %s completed in %v", r.Method, r.RequestURI, time.Since(start))
+		slog.Info("request started", "request_id", id, "method", r.Method, "path", r.URL.Path, "remote_addr", r.RemoteAddr)
+		next.ServeHTTP(rec, r)
+		duration := time.Since(start)
+		slog.Info("request completed", "request_id", id, "method", r.Method, "path", r.URL.Path, "status", rec.status, "duration", duration)
+
+		metrics.HTTPRequestsTotal.Inc(r.Method, metricsPath(r), strconv.Itoa(rec.status))
+		metrics.HTTPRequestDuration.Observe(duration.Seconds(), r.Method, metricsPath(r))
 	})
 }
 
+// metricsPath returns the route's registered template (e.g. "/containers/{name}")
+// rather than the request's literal URL, so per-container paths don't each
+// mint their own HTTP metric series.
+func metricsPath(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			return tmpl
+		}
+	}
+	return r.URL.Path
+}
+
 // CORSMiddleware enables cross-origin requests from web browsers
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -360,51 +377,81 @@ var startTime time.Time
 
 func main() {
 	startTime = time.Now()
-	
-	// Try to initialize PostgreSQL (falls back to JSON files if unavailable)
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-	
-	if dbHost != "" && dbPort != "" && dbUser != "" && dbPassword != "" && dbName != "" {
-		log.Println("Initializing PostgreSQL database connection...")
-		var err error
-		db, err = NewDatabase(dbHost, dbPort, dbUser, dbPassword, dbName)
+
+	// Select the storage backend. STORAGE_DRIVER chooses the driver;
+	// boltdb is the default since it needs no external service.
+	driver := os.Getenv("STORAGE_DRIVER")
+	if driver == "" {
+		driver = "boltdb"
+	}
+
+	switch driver {
+	case "postgres":
+		log.Println("Initializing PostgreSQL storage driver...")
+		s, err := postgres.New(
+			os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_USER"),
+			os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"))
 		if err != nil {
-			log.Printf("Warning: Failed to connect to database: %v", err)
-			log.Println("Falling back to file-based storage")
-			useDatabase = false
-		} else {
-			if err := db.InitializeSchema(); err != nil {  // Create tables if needed
-				log.Printf("Warning: Failed to initialize schema: %v", err)
-				log.Println("Falling back to file-based storage")
-				useDatabase = false
-			} else {
-				useDatabase = true
-				log.Println("Successfully connected to PostgreSQL database")
-				defer db.Close()
-			}
+			log.Fatalf("Failed to initialize PostgreSQL storage: %v", err)
 		}
-	} else {
-		log.Println("Database credentials not provided, using file-based storage")
-		useDatabase = false
+		store = s
+	case "inmemory":
+		log.Println("Initializing in-memory storage driver (data will not persist)...")
+		store = inmemory.New()
+	case "boltdb":
+		boltPath := os.Getenv("BOLT_PATH")
+		if boltPath == "" {
+			boltPath = DefaultBoltPath
+		}
+		log.Printf("Initializing BoltDB storage driver at %s...", boltPath)
+		s, err := boltdb.New(boltPath)
+		if err != nil {
+			log.Fatalf("Failed to initialize BoltDB storage: %v", err)
+		}
+		store = s
+	default:
+		log.Fatalf("Unknown STORAGE_DRIVER %q (expected postgres, boltdb, or inmemory)", driver)
 	}
-	
+	defer store.Close()
+
+	// RUNTIME_METRICS=true additionally exposes Go runtime stats
+	// (goroutines, heap, GC pauses) on GET /metrics.
+	if os.Getenv("RUNTIME_METRICS") == "true" {
+		metrics.EnableRuntimeMetrics()
+	}
+
+	// opManager.Sweep documents that it must be driven periodically or
+	// finished operations are retained forever; this is that driver.
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			opManager.Sweep()
+		}
+	}()
+
 	// Setup HTTP router with middleware
 	router := mux.NewRouter()
 	router.Use(LoggingMiddleware)  // Log all requests
 	router.Use(CORSMiddleware)     // Enable CORS for web clients
-	
+
 	// Register API endpoints
 	router.HandleFunc("/health", HealthCheckHandler).Methods("GET")
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
 	router.HandleFunc("/containers", CreateContainerHandler).Methods("POST")
 	router.HandleFunc("/containers", ListContainersHandler).Methods("GET")
 	router.HandleFunc("/containers/{name}", GetContainerHandler).Methods("GET")
 	router.HandleFunc("/containers/{name}", DeleteContainerHandler).Methods("DELETE")
 	router.HandleFunc("/containers/{name}/start", StartContainerHandler).Methods("POST")
-	
+	router.HandleFunc("/operations/{id}", OperationStatusHandler).Methods("GET")
+	router.HandleFunc("/events", EventsHandler).Methods("GET")
+	router.HandleFunc("/containers/{name}/exec", ExecCreateHandler).Methods("POST")
+	router.HandleFunc("/exec/{id}/ws", ExecWSHandler).Methods("GET")
+
+	// Docker Engine API v1.41 compatibility surface, mounted under /v1.41
+	// so `docker` CLI/SDKs work unchanged.
+	compat.NewHandlers(compatBackend{}).RegisterRoutes(router)
+
 	// Root endpoint with API documentation
 	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		info := map[string]interface{}{
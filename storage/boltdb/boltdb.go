@@ -0,0 +1,210 @@
+// Package boltdb implements storage.Store on top of BoltDB, for single-node
+// MiniRun deployments that don't want to run a separate PostgreSQL instance.
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/Raafay-Qureshi/minirun-container-runtime/errdefs"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/storage"
+)
+
+var (
+	containersBucket = []byte("containers")
+	metaBucket       = []byte("meta")
+	schemaVersionKey = []byte("schema_version")
+)
+
+// Store is a BoltDB-backed storage.Store implementation. BoltDB itself
+// serializes all writers, so no additional locking is needed here.
+type Store struct {
+	db *bolt.DB
+}
+
+// New opens (creating if necessary) the BoltDB file at path and ensures its
+// buckets and schema version exist.
+func New(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open boltdb at %s: %w", path, err)
+	}
+
+	s := &Store{db: db}
+	if err := s.init(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) init() error {
+	var currentVersion int
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(containersBucket); err != nil {
+			return err
+		}
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return err
+		}
+		raw := meta.Get(schemaVersionKey)
+		if raw == nil {
+			currentVersion = storage.SchemaVersion
+			return meta.Put(schemaVersionKey, []byte(fmt.Sprintf("%d", storage.SchemaVersion)))
+		}
+		currentVersion, err = strconv.Atoi(string(raw))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return s.Migrate(context.Background(), currentVersion)
+}
+
+// Close releases the underlying BoltDB file handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// CreateContainer inserts c, returning errdefs.Conflict if the name is taken.
+func (s *Store) CreateContainer(c *storage.Container) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(containersBucket)
+		if b.Get([]byte(c.Name)) != nil {
+			return errdefs.ConflictMsg(fmt.Sprintf("container '%s' already exists", c.Name))
+		}
+		data, err := json.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("failed to serialize container: %w", err)
+		}
+		return b.Put([]byte(c.Name), data)
+	})
+}
+
+// GetContainer returns the container with the given name.
+func (s *Store) GetContainer(name string) (*storage.Container, error) {
+	var container storage.Container
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(containersBucket).Get([]byte(name))
+		if data == nil {
+			return errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+		}
+		return json.Unmarshal(data, &container)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &container, nil
+}
+
+// ListContainers returns every container, newest first.
+func (s *Store) ListContainers() ([]storage.Container, error) {
+	containers := []storage.Container{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(containersBucket).ForEach(func(_, data []byte) error {
+			var c storage.Container
+			if err := json.Unmarshal(data, &c); err != nil {
+				return fmt.Errorf("failed to parse container: %w", err)
+			}
+			containers = append(containers, c)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].CreatedAt.After(containers[j].CreatedAt)
+	})
+	return containers, nil
+}
+
+// ListContainersFiltered returns the subset of containers matching opts.Filters,
+// newest first, truncated to opts.Limit.
+func (s *Store) ListContainersFiltered(opts storage.ListOptions) (storage.ListResult, error) {
+	containers, err := s.ListContainers()
+	if err != nil {
+		return storage.ListResult{}, err
+	}
+	return storage.FilterAndPaginate(containers, opts.Filters, opts.Limit), nil
+}
+
+// UpdateContainerStatus sets a container's status.
+func (s *Store) UpdateContainerStatus(name, status string) error {
+	return s.mutate(name, func(c *storage.Container) { c.Status = status })
+}
+
+// UpdateContainerLifecycle records pid/exit code/timestamps for a container.
+func (s *Store) UpdateContainerLifecycle(name, status string, pid, exitCode int, startedAt, finishedAt *time.Time) error {
+	return s.mutate(name, func(c *storage.Container) {
+		c.Status = status
+		c.Pid = pid
+		c.ExitCode = exitCode
+		if startedAt != nil {
+			c.StartedAt = startedAt
+		}
+		if finishedAt != nil {
+			c.FinishedAt = finishedAt
+		}
+	})
+}
+
+func (s *Store) mutate(name string, fn func(*storage.Container)) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(containersBucket)
+		data := b.Get([]byte(name))
+		if data == nil {
+			return errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+		}
+		var c storage.Container
+		if err := json.Unmarshal(data, &c); err != nil {
+			return fmt.Errorf("failed to parse container: %w", err)
+		}
+		fn(&c)
+		updated, err := json.Marshal(&c)
+		if err != nil {
+			return fmt.Errorf("failed to serialize container: %w", err)
+		}
+		return b.Put([]byte(name), updated)
+	})
+}
+
+// DeleteContainer removes a container by name.
+func (s *Store) DeleteContainer(name string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(containersBucket)
+		if b.Get([]byte(name)) == nil {
+			return errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+		}
+		return b.Delete([]byte(name))
+	})
+}
+
+// ContainerExists reports whether a container with the given name exists.
+func (s *Store) ContainerExists(name string) (bool, error) {
+	var exists bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		exists = tx.Bucket(containersBucket).Get([]byte(name)) != nil
+		return nil
+	})
+	return exists, err
+}
+
+// Migrate brings an existing BoltDB file from fromVersion up to
+// storage.SchemaVersion. There are no migrations yet; this just records the
+// current version so future schema changes have a baseline to compare against.
+func (s *Store) Migrate(ctx context.Context, fromVersion int) error {
+	if fromVersion >= storage.SchemaVersion {
+		return nil
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(metaBucket).Put(schemaVersionKey, []byte(fmt.Sprintf("%d", storage.SchemaVersion)))
+	})
+}
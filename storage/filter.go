@@ -0,0 +1,86 @@
+package storage
+
+import "strings"
+
+// FilterAndPaginate applies f to containers (already sorted newest-first)
+// and truncates to limit, returning the matching page alongside the total
+// match count before truncation. It's shared by drivers that keep the full
+// container set in memory (inmemory, boltdb) rather than pushing the filter
+// down into a query language.
+func FilterAndPaginate(containers []Container, f ListFilters, limit int) ListResult {
+	var sinceAt, beforeAt *Container
+	for i := range containers {
+		if f.Since != "" && containers[i].Name == f.Since {
+			sinceAt = &containers[i]
+		}
+		if f.Before != "" && containers[i].Name == f.Before {
+			beforeAt = &containers[i]
+		}
+	}
+
+	matched := make([]Container, 0, len(containers))
+	for _, c := range containers {
+		if !matchesFilters(c, f, sinceAt, beforeAt) {
+			continue
+		}
+		matched = append(matched, c)
+	}
+
+	total := len(matched)
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return ListResult{Containers: matched, Total: total}
+}
+
+func matchesFilters(c Container, f ListFilters, sinceAt, beforeAt *Container) bool {
+	if len(f.Status) > 0 && !containsString(f.Status, c.Status) {
+		return false
+	}
+	if len(f.Name) > 0 && !matchesAnyPrefix(f.Name, c.Name) {
+		return false
+	}
+	if len(f.Label) > 0 && !matchesAllLabels(f.Label, c.Labels) {
+		return false
+	}
+	if sinceAt != nil && !c.CreatedAt.After(sinceAt.CreatedAt) {
+		return false
+	}
+	if beforeAt != nil && !c.CreatedAt.Before(beforeAt.CreatedAt) {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyPrefix(prefixes []string, name string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAllLabels requires every "key=value" filter to have a matching
+// entry in labels; multiple filters on the same key still all must match.
+func matchesAllLabels(filters []string, labels map[string]string) bool {
+	for _, kv := range filters {
+		key, value, found := strings.Cut(kv, "=")
+		if !found {
+			continue
+		}
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
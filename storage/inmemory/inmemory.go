@@ -0,0 +1,131 @@
+// Package inmemory implements storage.Store with a plain in-process map.
+// It persists nothing across restarts; intended for tests and for
+// STORAGE_DRIVER=inmemory local runs where durability doesn't matter.
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Raafay-Qureshi/minirun-container-runtime/errdefs"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/storage"
+)
+
+// Store is a mutex-guarded map-backed storage.Store implementation.
+type Store struct {
+	mu         sync.RWMutex
+	containers map[string]storage.Container
+}
+
+// New constructs an empty Store.
+func New() *Store {
+	return &Store{containers: make(map[string]storage.Container)}
+}
+
+// CreateContainer inserts c, returning errdefs.Conflict if the name is taken.
+func (s *Store) CreateContainer(c *storage.Container) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.containers[c.Name]; exists {
+		return errdefs.ConflictMsg(fmt.Sprintf("container '%s' already exists", c.Name))
+	}
+	s.containers[c.Name] = *c
+	return nil
+}
+
+// GetContainer returns the container with the given name.
+func (s *Store) GetContainer(name string) (*storage.Container, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, ok := s.containers[name]
+	if !ok {
+		return nil, errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+	}
+	return &c, nil
+}
+
+// ListContainers returns every container, newest first.
+func (s *Store) ListContainers() ([]storage.Container, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	containers := make([]storage.Container, 0, len(s.containers))
+	for _, c := range s.containers {
+		containers = append(containers, c)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].CreatedAt.After(containers[j].CreatedAt)
+	})
+	return containers, nil
+}
+
+// ListContainersFiltered returns the subset of containers matching opts.Filters,
+// newest first, truncated to opts.Limit.
+func (s *Store) ListContainersFiltered(opts storage.ListOptions) (storage.ListResult, error) {
+	containers, err := s.ListContainers()
+	if err != nil {
+		return storage.ListResult{}, err
+	}
+	return storage.FilterAndPaginate(containers, opts.Filters, opts.Limit), nil
+}
+
+// UpdateContainerStatus sets a container's status.
+func (s *Store) UpdateContainerStatus(name, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.containers[name]
+	if !ok {
+		return errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+	}
+	c.Status = status
+	s.containers[name] = c
+	return nil
+}
+
+// UpdateContainerLifecycle records pid/exit code/timestamps for a container.
+func (s *Store) UpdateContainerLifecycle(name, status string, pid, exitCode int, startedAt, finishedAt *time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.containers[name]
+	if !ok {
+		return errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+	}
+	c.Status = status
+	c.Pid = pid
+	c.ExitCode = exitCode
+	if startedAt != nil {
+		c.StartedAt = startedAt
+	}
+	if finishedAt != nil {
+		c.FinishedAt = finishedAt
+	}
+	s.containers[name] = c
+	return nil
+}
+
+// DeleteContainer removes a container by name.
+func (s *Store) DeleteContainer(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.containers[name]; !ok {
+		return errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+	}
+	delete(s.containers, name)
+	return nil
+}
+
+// ContainerExists reports whether a container with the given name exists.
+func (s *Store) ContainerExists(name string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.containers[name]
+	return ok, nil
+}
+
+// Migrate is a no-op: there is no on-disk schema to evolve.
+func (s *Store) Migrate(ctx context.Context, fromVersion int) error { return nil }
+
+// Close is a no-op: there are no resources to release.
+func (s *Store) Close() error { return nil }
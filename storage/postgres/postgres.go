@@ -0,0 +1,434 @@
+// Package postgres implements storage.Store on top of PostgreSQL. It is the
+// original MiniRun backend, extracted here so it can sit alongside the
+// boltdb and inmemory drivers behind the same interface.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq" // PostgreSQL driver, also used to detect unique-violation errors
+
+	"github.com/Raafay-Qureshi/minirun-container-runtime/errdefs"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/metrics"
+	"github.com/Raafay-Qureshi/minirun-container-runtime/storage"
+)
+
+// trackQuery returns a func to defer at the top of a Store method; calling
+// it records the elapsed time against minirun_db_query_duration_seconds
+// under the given query name.
+func trackQuery(name string) func() {
+	start := time.Now()
+	return func() { metrics.DBQueryDuration.Observe(time.Since(start).Seconds(), name) }
+}
+
+// schemaVersionKey is the key initializeSchema stores the schema version
+// under, in a single-row key/value table, so Migrate can tell how far behind
+// an existing database is.
+const schemaVersionKey = "schema_version"
+
+// Store handles PostgreSQL operations with connection pooling.
+type Store struct {
+	conn *sql.DB
+}
+
+// New creates a database connection with pooling (25 max, 5 idle, 5min
+// lifetime), verifies it's reachable, and brings the schema up to date.
+func New(host, port, user, password, dbname string) (*Store, error) {
+	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		host, port, user, password, dbname)
+
+	conn, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := conn.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	conn.SetMaxOpenConns(25)
+	conn.SetMaxIdleConns(5)
+	conn.SetConnMaxLifetime(5 * time.Minute)
+
+	s := &Store{conn: conn}
+	if err := s.initializeSchema(context.Background()); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	version, err := s.currentSchemaVersion()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := s.Migrate(context.Background(), version); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// currentSchemaVersion reads the version schema_meta recorded on a previous
+// run, so New can tell Migrate how far behind a pre-existing database is.
+func (s *Store) currentSchemaVersion() (int, error) {
+	var raw string
+	err := s.conn.QueryRow(`SELECT value FROM schema_meta WHERE key = $1`, schemaVersionKey).Scan(&raw)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	version, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version %q: %w", raw, err)
+	}
+	return version, nil
+}
+
+// Close shuts down the database connection pool.
+func (s *Store) Close() error {
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
+
+// CreateContainer inserts new container with parameterized query (SQL injection safe).
+func (s *Store) CreateContainer(c *storage.Container) error {
+	defer trackQuery("create_container")()
+	query := `INSERT INTO containers (name, rootfs, command, status, created_at, updated_at, labels)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	labels, err := marshalLabels(c.Labels)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.conn.Exec(query,
+		c.Name, c.RootFS, c.Command, c.Status, c.CreatedAt, time.Now(), labels)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errdefs.WrapConflict(err, fmt.Sprintf("container '%s' already exists", c.Name))
+		}
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+
+	return nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique-constraint
+// violation (error code 23505), as raised by the containers.name UNIQUE index.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+const containerColumns = `name, rootfs, command, status, created_at, pid, exit_code, started_at, finished_at, labels`
+
+// scanContainer scans a row with containerColumns' column order into c.
+func scanContainer(row interface{ Scan(...interface{}) error }, c *storage.Container) error {
+	var labels []byte
+	if err := row.Scan(&c.Name, &c.RootFS, &c.Command, &c.Status, &c.CreatedAt,
+		&c.Pid, &c.ExitCode, &c.StartedAt, &c.FinishedAt, &labels); err != nil {
+		return err
+	}
+	return unmarshalLabels(labels, c)
+}
+
+// GetContainer retrieves container by name, returns errdefs.NotFound if missing.
+func (s *Store) GetContainer(name string) (*storage.Container, error) {
+	defer trackQuery("get_container")()
+	query := `SELECT ` + containerColumns + ` FROM containers WHERE name = $1`
+
+	var container storage.Container
+	err := scanContainer(s.conn.QueryRow(query, name), &container)
+
+	if err == sql.ErrNoRows {
+		return nil, errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+	}
+	if err != nil {
+		return nil, errdefs.WrapSystem(err, "failed to get container")
+	}
+
+	return &container, nil
+}
+
+// ListContainers retrieves all containers ordered by creation time (newest first).
+func (s *Store) ListContainers() ([]storage.Container, error) {
+	defer trackQuery("list_containers")()
+	query := `SELECT ` + containerColumns + ` FROM containers ORDER BY created_at DESC`
+
+	rows, err := s.conn.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	defer rows.Close()
+
+	containers := []storage.Container{}
+	for rows.Next() {
+		var container storage.Container
+		if err := scanContainer(rows, &container); err != nil {
+			return nil, fmt.Errorf("failed to scan container: %w", err)
+		}
+		containers = append(containers, container)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating containers: %w", err)
+	}
+
+	return containers, nil
+}
+
+// ListContainersFiltered translates opts into a parameterized WHERE clause
+// (status/name/label/since/before) and runs it against the containers
+// table, plus a matching COUNT(*) for the pre-pagination total.
+func (s *Store) ListContainersFiltered(opts storage.ListOptions) (storage.ListResult, error) {
+	defer trackQuery("list_containers_filtered")()
+	where, args := buildWhereClause(opts.Filters)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM containers ` + where
+	if err := s.conn.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return storage.ListResult{}, fmt.Errorf("failed to count containers: %w", err)
+	}
+
+	query := `SELECT ` + containerColumns + ` FROM containers ` + where + ` ORDER BY created_at DESC`
+	if opts.Limit > 0 {
+		args = append(args, opts.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := s.conn.Query(query, args...)
+	if err != nil {
+		return storage.ListResult{}, fmt.Errorf("failed to list containers: %w", err)
+	}
+	defer rows.Close()
+
+	containers := []storage.Container{}
+	for rows.Next() {
+		var container storage.Container
+		if err := scanContainer(rows, &container); err != nil {
+			return storage.ListResult{}, fmt.Errorf("failed to scan container: %w", err)
+		}
+		containers = append(containers, container)
+	}
+	if err := rows.Err(); err != nil {
+		return storage.ListResult{}, fmt.Errorf("error iterating containers: %w", err)
+	}
+
+	return storage.ListResult{Containers: containers, Total: total}, nil
+}
+
+// buildWhereClause translates f into a "WHERE ..." clause (or "" if f is
+// empty) plus its positional args, ready to append to a query string.
+// Values within a field are OR'd, except label pairs, which must all
+// match; different fields are AND'd.
+func buildWhereClause(f storage.ListFilters) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+	param := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(f.Status) > 0 {
+		placeholders := make([]string, len(f.Status))
+		for i, status := range f.Status {
+			placeholders[i] = param(status)
+		}
+		clauses = append(clauses, fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ", ")))
+	}
+
+	if len(f.Name) > 0 {
+		ors := make([]string, len(f.Name))
+		for i, name := range f.Name {
+			ors[i] = fmt.Sprintf("name LIKE %s", param(name+"%"))
+		}
+		clauses = append(clauses, "("+strings.Join(ors, " OR ")+")")
+	}
+
+	for _, kv := range f.Label {
+		key, value, found := strings.Cut(kv, "=")
+		if !found {
+			continue
+		}
+		clauses = append(clauses, fmt.Sprintf("labels->>%s = %s", param(key), param(value)))
+	}
+
+	if f.Since != "" {
+		clauses = append(clauses, fmt.Sprintf("created_at > (SELECT created_at FROM containers WHERE name = %s)", param(f.Since)))
+	}
+	if f.Before != "" {
+		clauses = append(clauses, fmt.Sprintf("created_at < (SELECT created_at FROM containers WHERE name = %s)", param(f.Before)))
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// marshalLabels serializes labels for storage in the jsonb column, treating
+// a nil map the same as an empty one.
+func marshalLabels(labels map[string]string) ([]byte, error) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize labels: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalLabels parses the jsonb column back into c.Labels. An empty or
+// NULL value decodes to an empty map.
+func unmarshalLabels(data []byte, c *storage.Container) error {
+	if len(data) == 0 {
+		c.Labels = map[string]string{}
+		return nil
+	}
+	return json.Unmarshal(data, &c.Labels)
+}
+
+// UpdateContainerStatus changes container status with automatic timestamp update.
+func (s *Store) UpdateContainerStatus(name, status string) error {
+	defer trackQuery("update_container_status")()
+	query := `UPDATE containers SET status = $1, updated_at = $2 WHERE name = $3`
+
+	result, err := s.conn.Exec(query, status, time.Now(), name)
+	if err != nil {
+		return fmt.Errorf("failed to update container status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errdefs.WrapSystem(err, "failed to get rows affected")
+	}
+
+	if rows == 0 {
+		return errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+	}
+
+	return nil
+}
+
+// UpdateContainerLifecycle sets status, pid and exit_code as given (callers
+// pass pid 0 on exit and exit code 0 on start); started_at and finished_at
+// are only overwritten when non-nil.
+func (s *Store) UpdateContainerLifecycle(name, status string, pid, exitCode int, startedAt, finishedAt *time.Time) error {
+	defer trackQuery("update_container_lifecycle")()
+	query := `UPDATE containers
+	          SET status = $1, pid = $2, exit_code = $3, started_at = COALESCE($4, started_at),
+	              finished_at = COALESCE($5, finished_at), updated_at = $6
+	          WHERE name = $7`
+
+	result, err := s.conn.Exec(query, status, pid, exitCode, startedAt, finishedAt, time.Now(), name)
+	if err != nil {
+		return fmt.Errorf("failed to update container lifecycle: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errdefs.WrapSystem(err, "failed to get rows affected")
+	}
+	if rows == 0 {
+		return errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+	}
+
+	return nil
+}
+
+// DeleteContainer removes container from database, returns errdefs.NotFound if missing.
+func (s *Store) DeleteContainer(name string) error {
+	defer trackQuery("delete_container")()
+	query := `DELETE FROM containers WHERE name = $1`
+
+	result, err := s.conn.Exec(query, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete container: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errdefs.WrapSystem(err, "failed to get rows affected")
+	}
+
+	if rows == 0 {
+		return errdefs.NotFoundMsg(fmt.Sprintf("container '%s' not found", name))
+	}
+
+	return nil
+}
+
+// ContainerExists quickly checks if container name is already taken.
+func (s *Store) ContainerExists(name string) (bool, error) {
+	defer trackQuery("container_exists")()
+	query := `SELECT EXISTS(SELECT 1 FROM containers WHERE name = $1)`
+
+	var exists bool
+	err := s.conn.QueryRow(query, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check container existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// initializeSchema creates tables and indexes if they don't exist (idempotent).
+func (s *Store) initializeSchema(ctx context.Context) error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS containers (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) UNIQUE NOT NULL,
+			rootfs VARCHAR(512) NOT NULL,
+			command VARCHAR(255) NOT NULL,
+			status VARCHAR(50) NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+		ALTER TABLE containers ADD COLUMN IF NOT EXISTS pid INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE containers ADD COLUMN IF NOT EXISTS exit_code INTEGER NOT NULL DEFAULT 0;
+		ALTER TABLE containers ADD COLUMN IF NOT EXISTS started_at TIMESTAMP;
+		ALTER TABLE containers ADD COLUMN IF NOT EXISTS finished_at TIMESTAMP;
+		ALTER TABLE containers ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '{}';
+		CREATE INDEX IF NOT EXISTS idx_containers_status ON containers(status);
+		CREATE INDEX IF NOT EXISTS idx_containers_created ON containers(created_at);
+		CREATE TABLE IF NOT EXISTS schema_meta (key VARCHAR(64) PRIMARY KEY, value VARCHAR(64) NOT NULL);
+		INSERT INTO schema_meta (key, value) VALUES ('schema_version', '1')
+			ON CONFLICT (key) DO NOTHING;
+	`
+
+	_, err := s.conn.ExecContext(ctx, schema)
+	if err != nil {
+		return fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	return nil
+}
+
+// Migrate brings an existing database from fromVersion up to
+// storage.SchemaVersion. initializeSchema already applies the additive
+// column changes idempotently, so Migrate just records the new version.
+func (s *Store) Migrate(ctx context.Context, fromVersion int) error {
+	if fromVersion >= storage.SchemaVersion {
+		return nil
+	}
+	if err := s.initializeSchema(ctx); err != nil {
+		return err
+	}
+	_, err := s.conn.ExecContext(ctx,
+		`UPDATE schema_meta SET value = $1 WHERE key = $2`,
+		fmt.Sprintf("%d", storage.SchemaVersion), schemaVersionKey)
+	if err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Package storage defines the persistence interface MiniRun's orchestrator
+// talks to, decoupling it from any one backend. Selecting a driver is a
+// runtime concern (the STORAGE_DRIVER env var); the orchestrator itself only
+// ever depends on the Store interface below.
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// SchemaVersion is the current on-disk/in-database schema version. Drivers
+// compare this against the version they find at startup and run Migrate to
+// catch up, so drivers can evolve independently of one another.
+//
+// v2 added the Container.Labels column used by list-endpoint filtering.
+const SchemaVersion = 2
+
+// Container is the storage-layer representation of a MiniRun container.
+// The orchestrator package aliases its own Container type to this one so
+// handlers, the compat adapter, and the lifecycle manager share one shape.
+type Container struct {
+	Name       string            `json:"name"`
+	RootFS     string            `json:"rootfs"`
+	Command    string            `json:"command"`
+	Status     string            `json:"status"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Pid        int               `json:"pid,omitempty"`
+	ExitCode   int               `json:"exit_code,omitempty"`
+	StartedAt  *time.Time        `json:"started_at,omitempty"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// ListFilters holds the Docker-style filter set accepted by the list
+// endpoints' `?filters={...}` query param. Values within a field are OR'd
+// together, except Label, whose pairs must all match; different fields are
+// AND'd (e.g. two statuses match either, but a status plus a name filter
+// must both match).
+type ListFilters struct {
+	Status []string // e.g. ["running", "created"]
+	Name   []string // prefix match against Container.Name
+	Label  []string // "key=value" pairs, matched against Container.Labels
+	Since  string   // container name: only include containers created after this one
+	Before string   // container name: only include containers created before this one
+}
+
+// ListOptions bundles ListFilters with the pagination knobs ListContainersFiltered accepts.
+type ListOptions struct {
+	Filters ListFilters
+	Limit   int // 0 means unlimited
+}
+
+// ListResult is the result of a filtered, paginated container listing.
+// Total counts every container matching Filters, before Limit is applied,
+// so callers can report it via a header like X-Total-Count.
+type ListResult struct {
+	Containers []Container
+	Total      int
+}
+
+// Store is the persistence interface every backend driver implements.
+// Methods mirror the operations the orchestrator's HTTP handlers need;
+// NotFound/Conflict conditions are reported via errdefs so callers can
+// switch on error kind rather than comparing strings.
+type Store interface {
+	CreateContainer(c *Container) error
+	GetContainer(name string) (*Container, error)
+	ListContainers() ([]Container, error)
+	ListContainersFiltered(opts ListOptions) (ListResult, error)
+	UpdateContainerStatus(name, status string) error
+	UpdateContainerLifecycle(name, status string, pid, exitCode int, startedAt, finishedAt *time.Time) error
+	DeleteContainer(name string) error
+	ContainerExists(name string) (bool, error)
+
+	// Migrate brings the store's on-disk schema from fromVersion up to
+	// SchemaVersion. Drivers that have no schema to evolve (e.g. inmemory)
+	// may implement this as a no-op. ctx bounds how long a driver may spend
+	// on migration statements that talk to an external database.
+	Migrate(ctx context.Context, fromVersion int) error
+
+	// Close releases any resources (connections, file handles) held by the driver.
+	Close() error
+}